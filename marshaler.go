@@ -0,0 +1,50 @@
+package metadata
+
+import "reflect"
+
+// MetadataMarshaler lets a type control its own serialized form instead of
+// going through the reflect-based conversion in parseValue, e.g. to satisfy
+// Kubernetes label/annotation value constraints.
+type MetadataMarshaler interface {
+	MarshalMetadata() (string, error)
+}
+
+// MetadataUnmarshaler is the Unmarshal counterpart of MetadataMarshaler.
+type MetadataUnmarshaler interface {
+	UnmarshalMetadata(string) error
+}
+
+var (
+	metadataMarshalerType   = reflect.TypeOf((*MetadataMarshaler)(nil)).Elem()
+	metadataUnmarshalerType = reflect.TypeOf((*MetadataUnmarshaler)(nil)).Elem()
+)
+
+// asMetadataMarshaler returns field as a MetadataMarshaler, trying the value
+// itself and then its address, so both value- and pointer-receiver
+// implementations are honored.
+func asMetadataMarshaler(field reflect.Value) (MetadataMarshaler, bool) {
+	if field.Type().Implements(metadataMarshalerType) {
+		return field.Interface().(MetadataMarshaler), true
+	}
+
+	if field.CanAddr() && field.Addr().Type().Implements(metadataMarshalerType) {
+		return field.Addr().Interface().(MetadataMarshaler), true
+	}
+
+	return nil, false
+}
+
+// asMetadataUnmarshaler returns field as a MetadataUnmarshaler. Since
+// UnmarshalMetadata mutates the receiver, only a pointer-receiver
+// implementation on an addressable field can satisfy it in practice.
+func asMetadataUnmarshaler(field reflect.Value) (MetadataUnmarshaler, bool) {
+	if field.CanAddr() && field.Addr().Type().Implements(metadataUnmarshalerType) {
+		return field.Addr().Interface().(MetadataUnmarshaler), true
+	}
+
+	if field.Type().Implements(metadataUnmarshalerType) {
+		return field.Interface().(MetadataUnmarshaler), true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,109 @@
+package metadata
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type Level int
+
+func (l Level) MarshalMetadata() (string, error) {
+	return strconv.Itoa(int(l)), nil
+}
+
+func (l *Level) UnmarshalMetadata(value string) error {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+
+	*l = Level(i)
+	return nil
+}
+
+type CustomData struct {
+	Level Level `annotation:"level"`
+}
+
+func TestMarshalCustomMarshaler(t *testing.T) {
+	data := CustomData{Level: 3}
+
+	metadata := v1.ObjectMeta{}
+	if err := Marshal(&metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := map[string]string{"prefix/level": "3"}
+	if !reflect.DeepEqual(metadata.Annotations, want) {
+		t.Errorf("Marshal() annotations = %v, want %v", metadata.Annotations, want)
+	}
+}
+
+func TestUnmarshalCustomUnmarshaler(t *testing.T) {
+	metadata := v1.ObjectMeta{
+		Annotations: map[string]string{"prefix/level": "3"},
+	}
+
+	data := CustomData{}
+	if err := Unmarshal(metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := CustomData{Level: 3}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Unmarshal() struct = %v, want %v", data, want)
+	}
+}
+
+type Token struct {
+	Value string
+}
+
+func (t Token) MarshalMetadata() (string, error) {
+	return "tok-" + t.Value, nil
+}
+
+func (t *Token) UnmarshalMetadata(value string) error {
+	t.Value = strings.TrimPrefix(value, "tok-")
+	return nil
+}
+
+// EmbeddedMarshalerData anonymously embeds a type that both implements
+// MetadataMarshaler and carries its own explicit tag, which should be
+// honored instead of flattening Token's fields into the parent.
+type EmbeddedMarshalerData struct {
+	Token `annotation:"token"`
+}
+
+func TestMarshalEmbeddedWithTag(t *testing.T) {
+	data := EmbeddedMarshalerData{Token: Token{Value: "abc"}}
+
+	metadata := v1.ObjectMeta{}
+	if err := Marshal(&metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := map[string]string{"prefix/token": "tok-abc"}
+	if !reflect.DeepEqual(metadata.Annotations, want) {
+		t.Errorf("Marshal() annotations = %v, want %v", metadata.Annotations, want)
+	}
+}
+
+func TestUnmarshalEmbeddedWithTag(t *testing.T) {
+	metadata := v1.ObjectMeta{
+		Annotations: map[string]string{"prefix/token": "tok-abc"},
+	}
+
+	data := EmbeddedMarshalerData{}
+	if err := Unmarshal(metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := EmbeddedMarshalerData{Token: Token{Value: "abc"}}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Unmarshal() struct = %v, want %v", data, want)
+	}
+}
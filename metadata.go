@@ -25,9 +25,10 @@ var (
 	sliceOfFloat32s  = reflect.TypeOf([]float32(nil))
 	sliceOfFloat64s  = reflect.TypeOf([]float64(nil))
 	sliceOfDurations = reflect.TypeOf([]time.Duration(nil))
+
+	timeTimeType = reflect.TypeOf(time.Time{})
 )
 
-// TODO: Add Marshal/Unmarshal with interfaces support
 type Readable interface {
 	Labels() map[string]string
 	Annotations() map[string]string
@@ -38,6 +39,16 @@ type Writeable interface {
 	SetAnnotations(m map[string]string)
 }
 
+// ReadWriteable combines Readable and Writeable for callers that both read
+// and mutate labels and annotations, e.g. MarshalInto needs to merge into
+// existing entries rather than overwrite them. Readable's getters are Labels
+// and Annotations, so a type whose getters are GetLabels and GetAnnotations,
+// as on v1.ObjectMeta, does not satisfy it.
+type ReadWriteable interface {
+	Readable
+	Writeable
+}
+
 func Unmarshal(metadata v1.ObjectMeta, data interface{}, prefix string) error {
 	refPtr := reflect.ValueOf(data)
 	if refPtr.Kind() != reflect.Ptr {
@@ -49,35 +60,101 @@ func Unmarshal(metadata v1.ObjectMeta, data interface{}, prefix string) error {
 		return ErrNotStructPointer
 	}
 
+	return unmarshalStruct(metadata, ref, prefix, "", nil, AnnotationsTarget)
+}
+
+// UnmarshalWithOptions behaves like Unmarshal, but derives keys for fields
+// without an explicit annotation/label tag using opts.NameMapper and reads
+// opts.TagPrefix instead of a separately passed prefix.
+func UnmarshalWithOptions(metadata v1.ObjectMeta, data interface{}, opts UnmarshalOptions) error {
+	refPtr := reflect.ValueOf(data)
+	if refPtr.Kind() != reflect.Ptr {
+		return ErrNotStructPointer
+	}
+
+	ref := refPtr.Elem()
+	if ref.Kind() != reflect.Struct {
+		return ErrNotStructPointer
+	}
+
+	return unmarshalStruct(metadata, ref, opts.TagPrefix, "", opts.NameMapper, opts.DefaultTarget)
+}
+
+// unmarshalStruct walks ref's fields, recursing into embedded and tagged
+// nested struct fields so their tags can be joined with "." onto tagPath. An
+// anonymous struct field is flattened into its parent unless it carries its
+// own explicit annotation/label tag or implements MetadataUnmarshaler, in
+// which case it's treated like any other field instead. Fields
+// without an explicit annotation/label tag are looked up via
+// nameMapper/defaultTarget when nameMapper is non-nil, and skipped otherwise.
+func unmarshalStruct(metadata v1.ObjectMeta, ref reflect.Value, prefix, tagPath string, nameMapper NameMapper, defaultTarget Target) error {
 	refType := ref.Type()
 
 	for i := 0; i < refType.NumField(); i++ {
 		refField := ref.Field(i)
 		refTypeField := refType.Field(i)
 
-		// Try to use annotations
-		dict := metadata.Annotations
-		tag, ok := refTypeField.Tag.Lookup("annotation")
-		if !ok {
-			// Fallback to using labels
-			tag, ok = refTypeField.Tag.Lookup("label")
-			if !ok {
+		if refTypeField.Anonymous && refField.Kind() == reflect.Struct && !hasExplicitTag(refTypeField) {
+			if _, ok := asMetadataUnmarshaler(refField); !ok {
+				if err := unmarshalStruct(metadata, refField, prefix, tagPath, nameMapper, defaultTarget); err != nil {
+					return err
+				}
 				continue
 			}
-
-			dict = metadata.Labels
 		}
 
-		key := fmt.Sprintf("%s/%s", prefix, tag)
-		value, ok := dict[key]
+		dict, opts, ok := fieldTag(refTypeField, metadata.Labels, metadata.Annotations, nameMapper, defaultTarget)
 		if !ok {
-			return ErrValueMissing
+			continue
+		}
+
+		tagPath := joinTag(tagPath, opts.Name)
+		key := fmt.Sprintf("%s/%s", prefix, tagPath)
+
+		fieldValue := allocPointer(refField)
+
+		if unmarshaler, ok := asMetadataUnmarshaler(fieldValue); ok {
+			value, err := lookupValue(dict, key, opts)
+			if err != nil {
+				return err
+			}
+
+			if err := unmarshaler.UnmarshalMetadata(value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch fieldValue.Kind() {
+		case reflect.Struct:
+			if fieldValue.Type() != timeTimeType {
+				if err := unmarshalStruct(metadata, fieldValue, prefix, tagPath, nameMapper, defaultTarget); err != nil {
+					return err
+				}
+				continue
+			}
+		case reflect.Map:
+			if err := unmarshalMap(dict, fieldValue.Type(), fieldValue, key, opts); err != nil {
+				return err
+			}
+			continue
 		}
 
-		err := parseValue(refTypeField, refField, value)
+		value, err := lookupValue(dict, key, opts)
 		if err != nil {
 			return err
 		}
+
+		if opts.Validate != "" {
+			if err := runValidator(opts.Validate, value); err != nil {
+				return err
+			}
+		}
+
+		elemTypeField := reflect.StructField{Type: fieldValue.Type()}
+		if err := parseValue(elemTypeField, fieldValue, value, opts); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -94,7 +171,30 @@ func Marshal(metadata *v1.ObjectMeta, data interface{}, prefix string) error {
 		return ErrNotStructPointer
 	}
 
-	refType := ref.Type()
+	if metadata.Labels == nil {
+		metadata.Labels = make(map[string]string)
+	}
+
+	if metadata.Annotations == nil {
+		metadata.Annotations = make(map[string]string)
+	}
+
+	return marshalStruct(metadata, ref, prefix, "", nil, AnnotationsTarget)
+}
+
+// MarshalWithOptions behaves like Marshal, but derives keys for fields
+// without an explicit annotation/label tag using opts.NameMapper and writes
+// opts.TagPrefix instead of a separately passed prefix.
+func MarshalWithOptions(metadata *v1.ObjectMeta, data interface{}, opts MarshalOptions) error {
+	refPtr := reflect.ValueOf(data)
+	if refPtr.Kind() != reflect.Ptr {
+		return ErrNotStructPointer
+	}
+
+	ref := refPtr.Elem()
+	if ref.Kind() != reflect.Struct {
+		return ErrNotStructPointer
+	}
 
 	if metadata.Labels == nil {
 		metadata.Labels = make(map[string]string)
@@ -104,42 +204,254 @@ func Marshal(metadata *v1.ObjectMeta, data interface{}, prefix string) error {
 		metadata.Annotations = make(map[string]string)
 	}
 
+	return marshalStruct(metadata, ref, opts.TagPrefix, "", opts.NameMapper, opts.DefaultTarget)
+}
+
+// marshalStruct is the Marshal counterpart of unmarshalStruct: it recurses
+// into embedded and tagged nested struct fields, joining their tags with "."
+// onto tagPath. An anonymous struct field is flattened into its parent
+// unless it carries its own explicit annotation/label tag or implements
+// MetadataMarshaler, in which case it's treated like any other field
+// instead. Fields without an explicit annotation/label tag are keyed via
+// nameMapper/defaultTarget when nameMapper is non-nil, and skipped
+// otherwise.
+func marshalStruct(metadata *v1.ObjectMeta, ref reflect.Value, prefix, tagPath string, nameMapper NameMapper, defaultTarget Target) error {
+	refType := ref.Type()
+
 	for i := 0; i < refType.NumField(); i++ {
 		refField := ref.Field(i)
 		refTypeField := refType.Field(i)
 
-		dict := metadata.Annotations
-		tag, ok := refTypeField.Tag.Lookup("annotation")
-		if !ok {
-			// Try to use labels
-			tag, ok = refTypeField.Tag.Lookup("label")
-			if !ok {
+		if refTypeField.Anonymous && refField.Kind() == reflect.Struct && !hasExplicitTag(refTypeField) {
+			if _, ok := asMetadataMarshaler(refField); !ok {
+				if err := marshalStruct(metadata, refField, prefix, tagPath, nameMapper, defaultTarget); err != nil {
+					return err
+				}
 				continue
 			}
+		}
+
+		dict, opts, ok := fieldTag(refTypeField, metadata.Labels, metadata.Annotations, nameMapper, defaultTarget)
+		if !ok {
+			continue
+		}
+
+		if opts.OmitEmpty && refField.IsZero() {
+			continue
+		}
+
+		tagPath := joinTag(tagPath, opts.Name)
+		key := fmt.Sprintf("%s/%s", prefix, tagPath)
+
+		// A nil pointer has nothing to serialize, so it's skipped entirely
+		// rather than writing an empty or placeholder entry.
+		if refField.Kind() == reflect.Ptr && refField.IsNil() {
+			continue
+		}
+
+		fieldValue := derefPointer(refField)
+
+		if marshaler, ok := asMetadataMarshaler(fieldValue); ok {
+			s, err := marshaler.MarshalMetadata()
+			if err != nil {
+				return err
+			}
 
-			dict = metadata.Labels
+			if err := writeValidated(dict, key, s, opts); err != nil {
+				return err
+			}
+			continue
 		}
 
-		key := fmt.Sprintf("%s/%s", prefix, tag)
+		switch fieldValue.Kind() {
+		case reflect.Struct:
+			if fieldValue.Type() == timeTimeType {
+				layout := opts.Layout
+				if layout == "" {
+					layout = time.RFC3339
+				}
+
+				t := fieldValue.Interface().(time.Time)
+				if err := writeValidated(dict, key, t.Format(layout), opts); err != nil {
+					return err
+				}
+				continue
+			}
 
-		if refField.Kind() == reflect.Slice {
+			if err := marshalStruct(metadata, fieldValue, prefix, tagPath, nameMapper, defaultTarget); err != nil {
+				return err
+			}
+			continue
+		case reflect.Map:
+			if err := marshalMap(dict, fieldValue, key, opts); err != nil {
+				return err
+			}
+			continue
+		case reflect.Slice:
 			var values []string
-			for i := 0; i < refField.Len(); i++ {
-				s := fmt.Sprint(refField.Index(i))
+			for i := 0; i < fieldValue.Len(); i++ {
+				s := fmt.Sprint(fieldValue.Index(i))
 				values = append(values, s)
 			}
 
-			dict[key] = strings.Join(values, ",")
-		} else {
-			dict[key] = fmt.Sprint(refField)
+			if err := writeValidated(dict, key, strings.Join(values, opts.Separator), opts); err != nil {
+				return err
+			}
+		default:
+			if err := writeValidated(dict, key, fmt.Sprint(fieldValue), opts); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// fieldTag resolves which map a field belongs to and its tag options. An
+// explicit `annotation` or `label` tag always wins; otherwise, if nameMapper
+// is set, the key is derived from the field's Go name and the field is
+// routed to defaultTarget. Returns ok == false if the field carries no tag
+// and nameMapper is nil, meaning it should be skipped.
+func fieldTag(refTypeField reflect.StructField, labels, annotations map[string]string, nameMapper NameMapper, defaultTarget Target) (dict map[string]string, opts tagOptions, ok bool) {
+	if tag, ok := refTypeField.Tag.Lookup("annotation"); ok {
+		return annotations, parseTagOptions(tag), true
+	}
+
+	if tag, ok := refTypeField.Tag.Lookup("label"); ok {
+		return labels, parseTagOptions(tag), true
+	}
+
+	if nameMapper == nil {
+		return nil, tagOptions{}, false
+	}
+
+	opts = tagOptions{Name: nameMapper(refTypeField.Name), Separator: ","}
+	if defaultTarget == LabelsTarget {
+		return labels, opts, true
+	}
+
+	return annotations, opts, true
+}
+
+// hasExplicitTag reports whether refTypeField carries its own annotation or
+// label tag, as opposed to one derived from a NameMapper.
+func hasExplicitTag(refTypeField reflect.StructField) bool {
+	if _, ok := refTypeField.Tag.Lookup("annotation"); ok {
+		return true
+	}
+
+	_, ok := refTypeField.Tag.Lookup("label")
+	return ok
+}
+
+// joinTag joins a nested tag path with a field's own tag, e.g. ("db", "host")
+// becomes "db.host". An empty tagPath yields the tag unchanged.
+func joinTag(tagPath, tag string) string {
+	if tagPath == "" {
+		return tag
+	}
+
+	return tagPath + "." + tag
+}
+
+// derefPointer follows a chain of non-nil pointers down to the pointee. The
+// caller is expected to have already handled the nil case.
+func derefPointer(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// allocPointer follows a chain of pointers down to the pointee, allocating
+// zero values along the way for any nil pointer it encounters.
+func allocPointer(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// unmarshalMap populates a map[string]T field from every entry in dict whose
+// key shares the field's prefix, e.g. "prefix/labels.<key>". T may be a
+// scalar or a slice of scalars, the latter split on opts.Separator the same
+// way a top-level slice field would be.
+func unmarshalMap(dict map[string]string, mapType reflect.Type, valueField reflect.Value, key string, opts tagOptions) error {
+	if mapType.Key().Kind() != reflect.String {
+		return ErrUnsupportedType
+	}
+
+	searchPrefix := key + "."
+	result := reflect.MakeMap(mapType)
+
+	for k, v := range dict {
+		if !strings.HasPrefix(k, searchPrefix) {
+			continue
+		}
+
+		mapKey := strings.TrimPrefix(k, searchPrefix)
+		elemValue := reflect.New(mapType.Elem()).Elem()
+		elemTypeField := reflect.StructField{Type: mapType.Elem()}
+
+		if err := parseValue(elemTypeField, elemValue, v, opts); err != nil {
+			return err
+		}
+
+		result.SetMapIndex(reflect.ValueOf(mapKey), elemValue)
+	}
+
+	valueField.Set(result)
+	return nil
+}
+
+// marshalMap writes every entry of a map[string]T field into dict under
+// "<key>.<map key>". T may be a scalar or a slice of scalars, the latter
+// joined with opts.Separator the same way a top-level slice field would be.
+func marshalMap(dict map[string]string, valueField reflect.Value, key string, opts tagOptions) error {
+	iter := valueField.MapRange()
+	for iter.Next() {
+		entryKey := fmt.Sprintf("%s.%s", key, iter.Key())
+		entryValue := iter.Value()
+
+		if entryValue.Kind() == reflect.Slice {
+			var values []string
+			for i := 0; i < entryValue.Len(); i++ {
+				values = append(values, fmt.Sprint(entryValue.Index(i)))
+			}
+
+			dict[entryKey] = strings.Join(values, opts.Separator)
+			continue
 		}
+
+		dict[entryKey] = fmt.Sprint(entryValue)
 	}
 
 	return nil
 }
 
-func parseValue(typeField reflect.StructField, valueField reflect.Value, value string) error {
+func parseValue(typeField reflect.StructField, valueField reflect.Value, value string, opts tagOptions) error {
 	switch typeField.Type.Kind() {
+	case reflect.Struct:
+		if typeField.Type != timeTimeType {
+			return ErrUnsupportedType
+		}
+
+		layout := opts.Layout
+		if layout == "" {
+			layout = time.RFC3339
+		}
+
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			return ErrConversion
+		}
+		valueField.Set(reflect.ValueOf(t))
 	case reflect.String:
 		valueField.SetString(value)
 	case reflect.Bool:
@@ -233,7 +545,7 @@ func parseValue(typeField reflect.StructField, valueField reflect.Value, value s
 		}
 		valueField.SetComplex(c)
 	case reflect.Slice:
-		err := handleSlice(valueField, value, ",")
+		err := handleSlice(valueField, value, opts.Separator)
 		if err != nil {
 			return ErrConversion
 		}
@@ -248,6 +560,11 @@ func handleSlice(field reflect.Value, value, separator string) error {
 		separator = ","
 	}
 
+	if value == "" {
+		field.Set(reflect.MakeSlice(field.Type(), 0, 0))
+		return nil
+	}
+
 	splitData := strings.Split(value, separator)
 
 	switch field.Type() {
@@ -302,9 +619,34 @@ func handleSlice(field reflect.Value, value, separator string) error {
 		}
 		field.Set(reflect.ValueOf(durationData))
 	default:
-		return ErrUnsupportedType
+		elemType := field.Type().Elem()
+		if elemType.Kind() != reflect.Ptr {
+			return ErrUnsupportedType
+		}
+
+		return handlePointerSlice(field, elemType, splitData)
+	}
+
+	return nil
+}
+
+// handlePointerSlice builds a slice of pointers to primitives (e.g.
+// []*int), parsing each element the same way parseValue would its
+// non-pointer counterpart.
+func handlePointerSlice(field reflect.Value, elemType reflect.Type, splitData []string) error {
+	result := reflect.MakeSlice(reflect.SliceOf(elemType), len(splitData), len(splitData))
+	elemTypeField := reflect.StructField{Type: elemType.Elem()}
+
+	for i, v := range splitData {
+		elemPtr := reflect.New(elemType.Elem())
+		if err := parseValue(elemTypeField, elemPtr.Elem(), v, tagOptions{Separator: ","}); err != nil {
+			return err
+		}
+
+		result.Index(i).Set(elemPtr)
 	}
 
+	field.Set(result)
 	return nil
 }
 
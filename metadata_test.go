@@ -13,6 +13,16 @@ type Data struct {
 	Skills []string `label:"skills"`
 }
 
+type DB struct {
+	Host string `annotation:"host"`
+	Port int    `annotation:"port"`
+}
+
+type NestedData struct {
+	DB     DB                `annotation:"db"`
+	Params map[string]string `annotation:"params"`
+}
+
 func TestUnmarshal(t *testing.T) {
 	type args struct {
 		metadata v1.ObjectMeta
@@ -110,3 +120,83 @@ func TestMarshall(t *testing.T) {
 		})
 	}
 }
+
+func TestMarshalNested(t *testing.T) {
+	data := NestedData{
+		DB:     DB{Host: "localhost", Port: 5432},
+		Params: map[string]string{"retries": "3"},
+	}
+
+	metadata := v1.ObjectMeta{}
+	if err := Marshal(&metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := map[string]string{
+		"prefix/db.host":        "localhost",
+		"prefix/db.port":        "5432",
+		"prefix/params.retries": "3",
+	}
+	if !reflect.DeepEqual(metadata.Annotations, want) {
+		t.Errorf("Marshal() annotations = %v, want %v", metadata.Annotations, want)
+	}
+}
+
+func TestUnmarshalNested(t *testing.T) {
+	metadata := v1.ObjectMeta{
+		Annotations: map[string]string{
+			"prefix/db.host":        "localhost",
+			"prefix/db.port":        "5432",
+			"prefix/params.retries": "3",
+		},
+	}
+
+	data := NestedData{}
+	if err := Unmarshal(metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := NestedData{
+		DB:     DB{Host: "localhost", Port: 5432},
+		Params: map[string]string{"retries": "3"},
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Unmarshal() struct = %v, want %v", data, want)
+	}
+}
+
+type GroupsData struct {
+	Groups map[string][]string `annotation:"groups"`
+}
+
+func TestMarshalMapOfSlices(t *testing.T) {
+	data := GroupsData{
+		Groups: map[string][]string{"admins": {"alice", "bob"}},
+	}
+
+	metadata := v1.ObjectMeta{}
+	if err := Marshal(&metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := map[string]string{"prefix/groups.admins": "alice,bob"}
+	if !reflect.DeepEqual(metadata.Annotations, want) {
+		t.Errorf("Marshal() annotations = %v, want %v", metadata.Annotations, want)
+	}
+}
+
+func TestUnmarshalMapOfSlices(t *testing.T) {
+	metadata := v1.ObjectMeta{
+		Annotations: map[string]string{"prefix/groups.admins": "alice,bob"},
+	}
+
+	data := GroupsData{}
+	if err := Unmarshal(metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := GroupsData{Groups: map[string][]string{"admins": {"alice", "bob"}}}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Unmarshal() struct = %v, want %v", data, want)
+	}
+}
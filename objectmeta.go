@@ -0,0 +1,76 @@
+package metadata
+
+import v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// MarshalInto marshals data into rw's labels/annotations via Readable and
+// Writeable instead of a concrete v1.ObjectMeta. A client-go object (Pod,
+// Deployment, a CRD with an embedded ObjectMeta, ...) does not satisfy
+// ReadWriteable as is: its ObjectMeta getters are GetLabels and
+// GetAnnotations, not Labels and Annotations. rw's existing labels/annotations
+// that aren't covered by data's tags (e.g. app.kubernetes.io/name,
+// pod-template-hash) are preserved rather than wiped out.
+func MarshalInto(rw ReadWriteable, data interface{}, prefix string) error {
+	metadata := v1.ObjectMeta{
+		Labels:      cloneStringMap(rw.Labels()),
+		Annotations: cloneStringMap(rw.Annotations()),
+	}
+
+	if err := Marshal(&metadata, data, prefix); err != nil {
+		return err
+	}
+
+	rw.SetLabels(metadata.Labels)
+	rw.SetAnnotations(metadata.Annotations)
+	return nil
+}
+
+// UnmarshalFrom is the Unmarshal counterpart of MarshalInto: it reads r's
+// labels/annotations via Readable instead of a concrete v1.ObjectMeta.
+func UnmarshalFrom(r Readable, data interface{}, prefix string) error {
+	metadata := v1.ObjectMeta{
+		Labels:      r.Labels(),
+		Annotations: r.Annotations(),
+	}
+
+	return Unmarshal(metadata, data, prefix)
+}
+
+// MarshalPatch marshals data the same way as Marshal, but instead of
+// mutating r it returns only the labels/annotations entries that are new or
+// changed relative to r's current state, ready to merge into a JSON-merge or
+// strategic-merge patch.
+func MarshalPatch(r Readable, data interface{}, prefix string) (labels, annotations map[string]string, err error) {
+	metadata := v1.ObjectMeta{
+		Labels:      cloneStringMap(r.Labels()),
+		Annotations: cloneStringMap(r.Annotations()),
+	}
+
+	if err := Marshal(&metadata, data, prefix); err != nil {
+		return nil, nil, err
+	}
+
+	return diffStringMap(r.Labels(), metadata.Labels), diffStringMap(r.Annotations(), metadata.Annotations), nil
+}
+
+func cloneStringMap(m map[string]string) map[string]string {
+	clone := make(map[string]string, len(m))
+	for k, v := range m {
+		clone[k] = v
+	}
+
+	return clone
+}
+
+// diffStringMap returns the entries of after that are missing from or
+// different in before.
+func diffStringMap(before, after map[string]string) map[string]string {
+	diff := make(map[string]string)
+
+	for k, v := range after {
+		if before[k] != v {
+			diff[k] = v
+		}
+	}
+
+	return diff
+}
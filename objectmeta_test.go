@@ -0,0 +1,85 @@
+package metadata
+
+import (
+	"reflect"
+	"testing"
+)
+
+type fakeObject struct {
+	labels      map[string]string
+	annotations map[string]string
+}
+
+func (o *fakeObject) Labels() map[string]string          { return o.labels }
+func (o *fakeObject) Annotations() map[string]string     { return o.annotations }
+func (o *fakeObject) SetLabels(m map[string]string)      { o.labels = m }
+func (o *fakeObject) SetAnnotations(m map[string]string) { o.annotations = m }
+
+func TestMarshalInto(t *testing.T) {
+	obj := &fakeObject{
+		labels:      map[string]string{"app": "myapp", "team": "payments"},
+		annotations: map[string]string{"kubectl.kubernetes.io/last-applied": "{}"},
+	}
+	data := Data{ID: 1, Name: "John", Age: 30, Skills: []string{"cooking"}}
+
+	if err := MarshalInto(obj, &data, "prefix"); err != nil {
+		t.Fatalf("MarshalInto() error = %v", err)
+	}
+
+	if obj.labels["app"] != "myapp" || obj.labels["team"] != "payments" {
+		t.Errorf("MarshalInto() wiped out pre-existing labels = %v", obj.labels)
+	}
+	if obj.annotations["kubectl.kubernetes.io/last-applied"] != "{}" {
+		t.Errorf("MarshalInto() wiped out pre-existing annotations = %v", obj.annotations)
+	}
+
+	if obj.annotations["prefix/id"] != "1" || obj.annotations["prefix/name"] != "John" {
+		t.Errorf("MarshalInto() annotations = %v", obj.annotations)
+	}
+	if obj.labels["prefix/age"] != "30" {
+		t.Errorf("MarshalInto() labels = %v", obj.labels)
+	}
+}
+
+func TestUnmarshalFrom(t *testing.T) {
+	obj := &fakeObject{
+		annotations: map[string]string{"prefix/id": "1", "prefix/name": "John"},
+		labels:      map[string]string{"prefix/age": "30", "prefix/skills": "cooking"},
+	}
+
+	data := Data{}
+	if err := UnmarshalFrom(obj, &data, "prefix"); err != nil {
+		t.Fatalf("UnmarshalFrom() error = %v", err)
+	}
+
+	want := Data{ID: 1, Name: "John", Age: 30, Skills: []string{"cooking"}}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("UnmarshalFrom() struct = %v, want %v", data, want)
+	}
+}
+
+func TestMarshalPatch(t *testing.T) {
+	obj := &fakeObject{
+		annotations: map[string]string{"prefix/id": "1", "prefix/name": "John", "other": "keep"},
+	}
+	data := Data{ID: 2, Name: "John", Age: 30, Skills: []string{"cooking"}}
+
+	labels, annotations, err := MarshalPatch(obj, &data, "prefix")
+	if err != nil {
+		t.Fatalf("MarshalPatch() error = %v", err)
+	}
+
+	wantAnnotations := map[string]string{"prefix/id": "2"}
+	if !reflect.DeepEqual(annotations, wantAnnotations) {
+		t.Errorf("MarshalPatch() annotations = %v, want %v", annotations, wantAnnotations)
+	}
+
+	wantLabels := map[string]string{"prefix/age": "30", "prefix/skills": "cooking"}
+	if !reflect.DeepEqual(labels, wantLabels) {
+		t.Errorf("MarshalPatch() labels = %v, want %v", labels, wantLabels)
+	}
+
+	if obj.annotations["other"] != "keep" {
+		t.Errorf("MarshalPatch() mutated obj's annotations: %v", obj.annotations)
+	}
+}
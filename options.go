@@ -0,0 +1,84 @@
+package metadata
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NameMapper derives a tag key from a Go field name for fields that don't
+// carry an explicit annotation/label tag.
+type NameMapper func(string) string
+
+// Target selects which of ObjectMeta's maps untagged fields are read from
+// or written to when a NameMapper is in use.
+type Target int
+
+const (
+	AnnotationsTarget Target = iota
+	LabelsTarget
+)
+
+// MarshalOptions configures MarshalWithOptions.
+type MarshalOptions struct {
+	// NameMapper derives a tag key for fields without an explicit
+	// annotation/label tag. Fields are skipped as before when left nil.
+	NameMapper NameMapper
+	// DefaultTarget selects which map fields without an explicit tag are
+	// written to.
+	DefaultTarget Target
+	// TagPrefix is used as the key prefix instead of a separately passed one.
+	TagPrefix string
+}
+
+// UnmarshalOptions configures UnmarshalWithOptions.
+type UnmarshalOptions struct {
+	// NameMapper derives a tag key for fields without an explicit
+	// annotation/label tag. Fields are skipped as before when left nil.
+	NameMapper NameMapper
+	// DefaultTarget selects which map fields without an explicit tag are
+	// read from.
+	DefaultTarget Target
+	// TagPrefix is used as the key prefix instead of a separately passed one.
+	TagPrefix string
+}
+
+// CamelToKebab derives a kebab-case key from a Go field name, e.g.
+// "MaxRetries" becomes "max-retries".
+func CamelToKebab(name string) string {
+	return splitCamel(name, "-")
+}
+
+// TitleUnderscore derives a snake_case key from a Go field name, e.g.
+// "MaxRetries" becomes "max_retries".
+func TitleUnderscore(name string) string {
+	return splitCamel(name, "_")
+}
+
+// LowerCamel derives a lowerCamelCase key from a Go field name, e.g.
+// "MaxRetries" becomes "maxRetries".
+func LowerCamel(name string) string {
+	if name == "" {
+		return name
+	}
+
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+func splitCamel(name, sep string) string {
+	var parts []string
+	var current strings.Builder
+
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			parts = append(parts, current.String())
+			current.Reset()
+		}
+		current.WriteRune(r)
+	}
+
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+
+	return strings.ToLower(strings.Join(parts, sep))
+}
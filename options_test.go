@@ -0,0 +1,76 @@
+package metadata
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"testing"
+)
+
+type AutoTagged struct {
+	MaxRetries int `annotation:"max-retries"`
+	UserName   string
+}
+
+func TestMarshalWithOptions(t *testing.T) {
+	data := AutoTagged{MaxRetries: 3, UserName: "john"}
+
+	metadata := v1.ObjectMeta{}
+	opts := MarshalOptions{
+		NameMapper:    CamelToKebab,
+		DefaultTarget: AnnotationsTarget,
+		TagPrefix:     "prefix",
+	}
+	if err := MarshalWithOptions(&metadata, &data, opts); err != nil {
+		t.Fatalf("MarshalWithOptions() error = %v", err)
+	}
+
+	want := map[string]string{
+		"prefix/max-retries": "3",
+		"prefix/user-name":   "john",
+	}
+	if !reflect.DeepEqual(metadata.Annotations, want) {
+		t.Errorf("MarshalWithOptions() annotations = %v, want %v", metadata.Annotations, want)
+	}
+}
+
+func TestUnmarshalWithOptions(t *testing.T) {
+	metadata := v1.ObjectMeta{
+		Annotations: map[string]string{
+			"prefix/max-retries": "3",
+			"prefix/user-name":   "john",
+		},
+	}
+
+	data := AutoTagged{}
+	opts := UnmarshalOptions{
+		NameMapper:    CamelToKebab,
+		DefaultTarget: AnnotationsTarget,
+		TagPrefix:     "prefix",
+	}
+	if err := UnmarshalWithOptions(metadata, &data, opts); err != nil {
+		t.Fatalf("UnmarshalWithOptions() error = %v", err)
+	}
+
+	want := AutoTagged{MaxRetries: 3, UserName: "john"}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("UnmarshalWithOptions() struct = %v, want %v", data, want)
+	}
+}
+
+func TestCamelToKebab(t *testing.T) {
+	if got := CamelToKebab("MaxRetries"); got != "max-retries" {
+		t.Errorf("CamelToKebab() = %v, want max-retries", got)
+	}
+}
+
+func TestTitleUnderscore(t *testing.T) {
+	if got := TitleUnderscore("MaxRetries"); got != "max_retries" {
+		t.Errorf("TitleUnderscore() = %v, want max_retries", got)
+	}
+}
+
+func TestLowerCamel(t *testing.T) {
+	if got := LowerCamel("MaxRetries"); got != "maxRetries" {
+		t.Errorf("LowerCamel() = %v, want maxRetries", got)
+	}
+}
@@ -0,0 +1,127 @@
+package metadata
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type PointerData struct {
+	Age     *int  `annotation:"age"`
+	Retired *bool `annotation:"retired"`
+}
+
+func TestMarshalPointer(t *testing.T) {
+	age := 42
+	data := PointerData{Age: &age}
+
+	metadata := v1.ObjectMeta{}
+	if err := Marshal(&metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if got := metadata.Annotations["prefix/age"]; got != "42" {
+		t.Errorf("Marshal() prefix/age = %v, want 42", got)
+	}
+	if _, ok := metadata.Annotations["prefix/retired"]; ok {
+		t.Errorf("Marshal() wrote prefix/retired for a nil pointer")
+	}
+}
+
+func TestUnmarshalPointer(t *testing.T) {
+	metadata := v1.ObjectMeta{
+		Annotations: map[string]string{"prefix/age": "42", "prefix/retired": "false"},
+	}
+
+	data := PointerData{}
+	if err := Unmarshal(metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if data.Age == nil || *data.Age != 42 {
+		t.Errorf("Unmarshal() Age = %v, want 42", data.Age)
+	}
+}
+
+type TimeData struct {
+	CreatedAt time.Time `annotation:"created"`
+	ExpiresAt time.Time `annotation:"expires,layout=2006-01-02"`
+}
+
+func TestMarshalTime(t *testing.T) {
+	data := TimeData{
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		ExpiresAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	metadata := v1.ObjectMeta{}
+	if err := Marshal(&metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := map[string]string{
+		"prefix/created": "2024-01-02T03:04:05Z",
+		"prefix/expires": "2024-01-02",
+	}
+	if !reflect.DeepEqual(metadata.Annotations, want) {
+		t.Errorf("Marshal() annotations = %v, want %v", metadata.Annotations, want)
+	}
+}
+
+func TestUnmarshalTime(t *testing.T) {
+	metadata := v1.ObjectMeta{
+		Annotations: map[string]string{
+			"prefix/created": "2024-01-02T03:04:05Z",
+			"prefix/expires": "2024-01-02",
+		},
+	}
+
+	data := TimeData{}
+	if err := Unmarshal(metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := TimeData{
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		ExpiresAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	if !data.CreatedAt.Equal(want.CreatedAt) || !data.ExpiresAt.Equal(want.ExpiresAt) {
+		t.Errorf("Unmarshal() struct = %v, want %v", data, want)
+	}
+}
+
+func TestUnmarshalEmptySlice(t *testing.T) {
+	metadata := v1.ObjectMeta{
+		Annotations: map[string]string{"prefix/id": "1", "prefix/name": "John"},
+		Labels:      map[string]string{"prefix/age": "30", "prefix/skills": ""},
+	}
+
+	data := Data{}
+	if err := Unmarshal(metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if len(data.Skills) != 0 {
+		t.Errorf("Unmarshal() Skills = %v, want empty slice", data.Skills)
+	}
+}
+
+type PointerSliceData struct {
+	Ages []*int `annotation:"ages"`
+}
+
+func TestUnmarshalPointerSlice(t *testing.T) {
+	metadata := v1.ObjectMeta{
+		Annotations: map[string]string{"prefix/ages": "1,2,3"},
+	}
+
+	data := PointerSliceData{}
+	if err := Unmarshal(metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if len(data.Ages) != 3 || *data.Ages[0] != 1 || *data.Ages[1] != 2 || *data.Ages[2] != 3 {
+		t.Errorf("Unmarshal() Ages = %v, want [1 2 3]", data.Ages)
+	}
+}
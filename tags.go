@@ -0,0 +1,121 @@
+package metadata
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var ErrValidatorNotFound = errors.New("validator is not registered")
+
+// tagOptions holds the parsed pieces of an `annotation`/`label` tag, e.g.
+// `annotation:"expiry,omitempty,default=24h"` or `label:"skills,sep=;,required"`.
+type tagOptions struct {
+	Name       string
+	OmitEmpty  bool
+	Required   bool
+	Default    string
+	HasDefault bool
+	Separator  string
+	Validate   string
+	Layout     string
+}
+
+// parseTagOptions splits a raw `annotation`/`label` tag value into its key
+// name and comma-separated options.
+func parseTagOptions(tag string) tagOptions {
+	parts := strings.Split(tag, ",")
+	opts := tagOptions{Name: parts[0], Separator: ","}
+
+	for _, part := range parts[1:] {
+		switch {
+		case part == "omitempty":
+			opts.OmitEmpty = true
+		case part == "required":
+			opts.Required = true
+		case strings.HasPrefix(part, "default="):
+			opts.Default = strings.TrimPrefix(part, "default=")
+			opts.HasDefault = true
+		case strings.HasPrefix(part, "sep="):
+			opts.Separator = strings.TrimPrefix(part, "sep=")
+		case strings.HasPrefix(part, "validate="):
+			opts.Validate = strings.TrimPrefix(part, "validate=")
+		case strings.HasPrefix(part, "layout="):
+			opts.Layout = strings.TrimPrefix(part, "layout=")
+		}
+	}
+
+	return opts
+}
+
+// lookupValue reads key out of dict, falling back to opts.Default when it's
+// missing and applying, unless opts.Required demands a hard error instead.
+func lookupValue(dict map[string]string, key string, opts tagOptions) (string, error) {
+	if value, ok := dict[key]; ok {
+		return value, nil
+	}
+
+	if opts.Required {
+		return "", ErrValueMissing
+	}
+
+	if opts.HasDefault {
+		return opts.Default, nil
+	}
+
+	return "", ErrValueMissing
+}
+
+// writeValidated runs opts.Validate over value, if set, before storing it in
+// dict under key.
+func writeValidated(dict map[string]string, key, value string, opts tagOptions) error {
+	if opts.Validate != "" {
+		if err := runValidator(opts.Validate, value); err != nil {
+			return err
+		}
+	}
+
+	dict[key] = value
+	return nil
+}
+
+// Validator checks a serialized value, e.g. to enforce Kubernetes label
+// constraints. It's referenced from a tag via the `validate=<name>` option.
+type Validator func(value string) error
+
+var validators = map[string]Validator{
+	"k8sLabelValue": k8sLabelValue,
+}
+
+// RegisterValidator makes fn available to the `validate=<name>` tag option
+// under the given name, overwriting any existing validator registered under
+// it.
+func RegisterValidator(name string, fn Validator) {
+	validators[name] = fn
+}
+
+func runValidator(name, value string) error {
+	fn, ok := validators[name]
+	if !ok {
+		return ErrValidatorNotFound
+	}
+
+	return fn(value)
+}
+
+var k8sLabelValueRegexp = regexp.MustCompile(`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`)
+
+// k8sLabelValue enforces the Kubernetes label value constraints: at most 63
+// characters, and either empty or starting and ending with an alphanumeric
+// character, with dashes, underscores, dots, and alphanumerics in between.
+func k8sLabelValue(value string) error {
+	if len(value) > 63 {
+		return errors.New("value exceeds 63 characters")
+	}
+
+	if !k8sLabelValueRegexp.MatchString(value) {
+		return errors.New("value isn't a valid Kubernetes label value")
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package metadata
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"testing"
+)
+
+type TaggedOptions struct {
+	Expiry string   `annotation:"expiry,omitempty,default=24h"`
+	Skills []string `label:"skills,sep=;,required"`
+}
+
+func TestMarshalOmitEmpty(t *testing.T) {
+	data := TaggedOptions{Skills: []string{"cooking", "swimming"}}
+
+	metadata := v1.ObjectMeta{}
+	if err := Marshal(&metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if _, ok := metadata.Annotations["prefix/expiry"]; ok {
+		t.Errorf("Marshal() wrote prefix/expiry despite omitempty and zero value")
+	}
+
+	want := "cooking;swimming"
+	if got := metadata.Labels["prefix/skills"]; got != want {
+		t.Errorf("Marshal() prefix/skills = %v, want %v", got, want)
+	}
+}
+
+func TestUnmarshalDefault(t *testing.T) {
+	metadata := v1.ObjectMeta{
+		Labels: map[string]string{"prefix/skills": "cooking;swimming"},
+	}
+
+	data := TaggedOptions{}
+	if err := Unmarshal(metadata, &data, "prefix"); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := TaggedOptions{Expiry: "24h", Skills: []string{"cooking", "swimming"}}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Unmarshal() struct = %v, want %v", data, want)
+	}
+}
+
+func TestUnmarshalRequiredMissing(t *testing.T) {
+	metadata := v1.ObjectMeta{}
+
+	data := TaggedOptions{}
+	if err := Unmarshal(metadata, &data, "prefix"); err != ErrValueMissing {
+		t.Errorf("Unmarshal() error = %v, want %v", err, ErrValueMissing)
+	}
+}
+
+func TestMarshalValidate(t *testing.T) {
+	type Validated struct {
+		Name string `annotation:"name,validate=k8sLabelValue"`
+	}
+
+	metadata := v1.ObjectMeta{}
+	data := Validated{Name: "not a valid label!"}
+	if err := Marshal(&metadata, &data, "prefix"); err == nil {
+		t.Errorf("Marshal() error = nil, want a validation error")
+	}
+}